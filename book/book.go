@@ -0,0 +1,238 @@
+// Package book maintains a local, continuously-updated view of a venue's
+// order book so strategy code can read best bid/ask, depth, and VWAP
+// without polling the REST API on every tick.
+package book
+
+import (
+	"sort"
+	"sync"
+)
+
+type Side bool
+
+const (
+	Bid Side = true
+	Ask Side = false
+)
+
+type Order struct {
+	Price    uint64
+	Quantity uint64
+}
+
+// Diff is one price-level update emitted on OrderBook's Diffs channel. A
+// Quantity of 0 means the level was removed.
+type Diff struct {
+	Side     Side
+	Price    uint64
+	Quantity uint64
+}
+
+// OrderBook aggregates per-price-level quantity for one (venue, stock)
+// market. Seed it from a REST snapshot, then keep it current with Apply
+// as tape/execution updates arrive.
+type OrderBook struct {
+	venue string
+	stock string
+
+	mu   sync.RWMutex
+	bids map[uint64]uint64
+	asks map[uint64]uint64
+
+	diff chan Diff
+}
+
+func New(venue, stock string) *OrderBook {
+	return &OrderBook{
+		venue: venue,
+		stock: stock,
+		bids:  make(map[uint64]uint64),
+		asks:  make(map[uint64]uint64),
+		diff:  make(chan Diff, 256),
+	}
+}
+
+func (b *OrderBook) Venue() string { return b.venue }
+func (b *OrderBook) Stock() string { return b.stock }
+
+// Seed replaces the book wholesale with a full snapshot, e.g. the result
+// of a REST orderbook fetch.
+func (b *OrderBook) Seed(bids, asks []Order) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bids = make(map[uint64]uint64, len(bids))
+	for _, o := range bids {
+		b.bids[o.Price] = o.Quantity
+	}
+
+	b.asks = make(map[uint64]uint64, len(asks))
+	for _, o := range asks {
+		b.asks[o.Price] = o.Quantity
+	}
+}
+
+// Apply sets a single price level and publishes the resulting delta on
+// Diffs. A quantity of 0 removes the level.
+func (b *OrderBook) Apply(side Side, price, quantity uint64) {
+	b.mu.Lock()
+	levels := b.asks
+	if side == Bid {
+		levels = b.bids
+	}
+
+	if quantity == 0 {
+		delete(levels, price)
+	} else {
+		levels[price] = quantity
+	}
+	b.mu.Unlock()
+
+	select {
+	case b.diff <- Diff{Side: side, Price: price, Quantity: quantity}:
+	default:
+	}
+}
+
+// Decrement reduces a price level by qty, e.g. for a partial fill,
+// removing the level once it reaches zero. It is a no-op for a price
+// with no resting level.
+func (b *OrderBook) Decrement(side Side, price, qty uint64) {
+	b.mu.Lock()
+	levels := b.asks
+	if side == Bid {
+		levels = b.bids
+	}
+
+	remaining, ok := levels[price]
+	if !ok {
+		b.mu.Unlock()
+		return
+	}
+
+	var newQty uint64
+	if qty < remaining {
+		newQty = remaining - qty
+		levels[price] = newQty
+	} else {
+		delete(levels, price)
+	}
+	b.mu.Unlock()
+
+	select {
+	case b.diff <- Diff{Side: side, Price: price, Quantity: newQty}:
+	default:
+	}
+}
+
+// Diffs delivers every Apply as it happens. Slow consumers miss deltas
+// rather than blocking the book.
+func (b *OrderBook) Diffs() <-chan Diff {
+	return b.diff
+}
+
+func (b *OrderBook) Best() (bid, ask Order) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return bestOf(b.bids, true), bestOf(b.asks, false)
+}
+
+func bestOf(levels map[uint64]uint64, highest bool) Order {
+	var best Order
+	found := false
+
+	for price, qty := range levels {
+		if !found || (highest && price > best.Price) || (!highest && price < best.Price) {
+			best = Order{Price: price, Quantity: qty}
+			found = true
+		}
+	}
+
+	return best
+}
+
+// Depth returns the n best levels on each side, best price first. n <= 0
+// returns every level.
+func (b *OrderBook) Depth(n int) (bids, asks []Order) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return topN(b.bids, n, true), topN(b.asks, n, false)
+}
+
+func topN(levels map[uint64]uint64, n int, highest bool) []Order {
+	orders := make([]Order, 0, len(levels))
+	for price, qty := range levels {
+		orders = append(orders, Order{Price: price, Quantity: qty})
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		if highest {
+			return orders[i].Price > orders[j].Price
+		}
+		return orders[i].Price < orders[j].Price
+	})
+
+	if n > 0 && n < len(orders) {
+		orders = orders[:n]
+	}
+
+	return orders
+}
+
+func (b *OrderBook) MidPrice() float64 {
+	bid, ask := b.Best()
+	if bid.Price == 0 || ask.Price == 0 {
+		return 0
+	}
+
+	return float64(bid.Price+ask.Price) / 2
+}
+
+func (b *OrderBook) Spread() uint64 {
+	bid, ask := b.Best()
+	if bid.Price == 0 || ask.Price == 0 || ask.Price < bid.Price {
+		return 0
+	}
+
+	return ask.Price - bid.Price
+}
+
+// VWAP walks side from its best price outward and returns the volume-
+// weighted average price to fill qty. ok is false when the book doesn't
+// have enough depth.
+func (b *OrderBook) VWAP(side Side, qty uint64) (vwap float64, ok bool) {
+	if qty == 0 {
+		return 0, false
+	}
+
+	bids, asks := b.Depth(0)
+	levels := asks
+	if side == Bid {
+		levels = bids
+	}
+
+	remaining := qty
+	var notional float64
+
+	for _, o := range levels {
+		if remaining == 0 {
+			break
+		}
+
+		take := o.Quantity
+		if take > remaining {
+			take = remaining
+		}
+
+		notional += float64(o.Price) * float64(take)
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		return 0, false
+	}
+
+	return notional / float64(qty), true
+}
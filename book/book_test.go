@@ -0,0 +1,140 @@
+package book
+
+import "testing"
+
+func newSeeded() *OrderBook {
+	b := New("TESTEX", "FOO")
+	b.Seed(
+		[]Order{{Price: 100, Quantity: 5}, {Price: 99, Quantity: 10}},
+		[]Order{{Price: 101, Quantity: 5}, {Price: 102, Quantity: 10}},
+	)
+	return b
+}
+
+func TestBest(t *testing.T) {
+	b := newSeeded()
+
+	bid, ask := b.Best()
+	if bid.Price != 100 || bid.Quantity != 5 {
+		t.Fatalf("best bid = %+v, want price 100 qty 5", bid)
+	}
+	if ask.Price != 101 || ask.Quantity != 5 {
+		t.Fatalf("best ask = %+v, want price 101 qty 5", ask)
+	}
+}
+
+func TestBestEmpty(t *testing.T) {
+	b := New("TESTEX", "FOO")
+
+	bid, ask := b.Best()
+	if bid != (Order{}) || ask != (Order{}) {
+		t.Fatalf("best on empty book = %+v / %+v, want zero values", bid, ask)
+	}
+}
+
+func TestDepth(t *testing.T) {
+	b := newSeeded()
+
+	bids, asks := b.Depth(1)
+	if len(bids) != 1 || bids[0].Price != 100 {
+		t.Fatalf("Depth(1) bids = %+v, want [{100 5}]", bids)
+	}
+	if len(asks) != 1 || asks[0].Price != 101 {
+		t.Fatalf("Depth(1) asks = %+v, want [{101 5}]", asks)
+	}
+
+	bids, asks = b.Depth(0)
+	if len(bids) != 2 || len(asks) != 2 {
+		t.Fatalf("Depth(0) = %d bids, %d asks, want 2 and 2", len(bids), len(asks))
+	}
+}
+
+func TestSpreadAndMidPrice(t *testing.T) {
+	b := newSeeded()
+
+	if spread := b.Spread(); spread != 1 {
+		t.Fatalf("Spread() = %d, want 1", spread)
+	}
+
+	if mid := b.MidPrice(); mid != 100.5 {
+		t.Fatalf("MidPrice() = %v, want 100.5", mid)
+	}
+}
+
+func TestSpreadOneSidedBook(t *testing.T) {
+	b := New("TESTEX", "FOO")
+	b.Apply(Bid, 100, 5)
+
+	if spread := b.Spread(); spread != 0 {
+		t.Fatalf("Spread() with no asks = %d, want 0", spread)
+	}
+	if mid := b.MidPrice(); mid != 0 {
+		t.Fatalf("MidPrice() with no asks = %v, want 0", mid)
+	}
+}
+
+func TestVWAP(t *testing.T) {
+	b := newSeeded()
+
+	vwap, ok := b.VWAP(Ask, 10)
+	if !ok {
+		t.Fatalf("VWAP(Ask, 10) ok = false, want true")
+	}
+	// 5 @ 101 + 5 @ 102 = 1015, / 10 = 101.5
+	if vwap != 101.5 {
+		t.Fatalf("VWAP(Ask, 10) = %v, want 101.5", vwap)
+	}
+}
+
+func TestVWAPZeroQty(t *testing.T) {
+	b := newSeeded()
+
+	vwap, ok := b.VWAP(Ask, 0)
+	if ok {
+		t.Fatalf("VWAP(Ask, 0) ok = true, want false")
+	}
+	if vwap != 0 {
+		t.Fatalf("VWAP(Ask, 0) = %v, want 0", vwap)
+	}
+}
+
+func TestVWAPInsufficientDepth(t *testing.T) {
+	b := newSeeded()
+
+	if _, ok := b.VWAP(Ask, 1000); ok {
+		t.Fatalf("VWAP(Ask, 1000) ok = true, want false (book only has 15 on the ask side)")
+	}
+}
+
+func TestDecrementPartialFill(t *testing.T) {
+	b := newSeeded()
+
+	b.Decrement(Bid, 100, 2)
+
+	bid, _ := b.Best()
+	if bid.Quantity != 3 {
+		t.Fatalf("bid qty after Decrement(2) = %d, want 3", bid.Quantity)
+	}
+}
+
+func TestDecrementRemovesLevel(t *testing.T) {
+	b := newSeeded()
+
+	b.Decrement(Bid, 100, 5)
+
+	bid, _ := b.Best()
+	if bid.Price != 99 {
+		t.Fatalf("best bid after exhausting top level = %+v, want price 99", bid)
+	}
+}
+
+func TestDecrementUnknownLevel(t *testing.T) {
+	b := newSeeded()
+
+	b.Decrement(Bid, 50, 1)
+
+	bids, _ := b.Depth(0)
+	if len(bids) != 2 {
+		t.Fatalf("Decrement on unknown level changed book, got %d bids, want 2", len(bids))
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gbarboza/stockfighter-client/book"
+)
+
+// NewOrderBook seeds a book.OrderBook from a REST snapshot and keeps it
+// current off the quote and execution WS streams, so strategy code gets
+// a low-latency in-memory view instead of polling FetchQuote/
+// FetchOrderbook every tick. The Stockfighter tape only carries top-of-
+// book, so only the best bid/ask level is kept live between snapshots;
+// callers that need fresh depth beyond the top should reseed.
+func (c *Client) NewOrderBook(ctx context.Context, venue string, stock string, account string) (*book.OrderBook, Unsubscribe, error) {
+	snapshot, err := c.FetchOrderbook(ctx, venue, stock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := book.New(venue, stock)
+	b.Seed(toBookOrders(snapshot.Bids), toBookOrders(snapshot.Asks))
+
+	quotes, unsubQuotes := c.SubscribeQuotes(venue, account, stock)
+	executions, unsubExecutions := c.SubscribeExecutions(venue, account)
+
+	go func() {
+		for {
+			select {
+			case q, ok := <-quotes:
+				if !ok {
+					return
+				}
+				applyQuote(b, q.Quote)
+			case e, ok := <-executions:
+				if !ok {
+					return
+				}
+				applyExecution(b, e)
+			}
+		}
+	}()
+
+	unsubscribe := Unsubscribe(func() {
+		unsubQuotes()
+		unsubExecutions()
+	})
+
+	return b, unsubscribe, nil
+}
+
+func toBookOrders(entries []OrderbookEntry) []book.Order {
+	orders := make([]book.Order, len(entries))
+	for i, e := range entries {
+		orders[i] = book.Order{Price: e.Price, Quantity: e.Quantity}
+	}
+
+	return orders
+}
+
+func applyQuote(b *book.OrderBook, q QuoteResponse) {
+	if q.Bid > 0 {
+		b.Apply(book.Bid, uint64(q.Bid), uint64(q.BidSize))
+	}
+
+	if q.Ask > 0 {
+		b.Apply(book.Ask, uint64(q.Ask), uint64(q.AskSize))
+	}
+}
+
+func applyExecution(b *book.OrderBook, e ExecutionEvent) {
+	if e.Price <= 0 || e.Filled <= 0 {
+		return
+	}
+
+	// A fill consumes the standing order's side: a resting sell rests on
+	// the ask side, so it's the asks that shrink. e.Order may be either
+	// the standing or the incoming order; when it is the incoming one the
+	// standing order is on the opposite side.
+	standingSells := e.Order.Direction == string(SELL)
+	if e.Order.Id != e.StandingId {
+		standingSells = !standingSells
+	}
+
+	side := book.Bid
+	if standingSells {
+		side = book.Ask
+	}
+
+	b.Decrement(side, uint64(e.Price), uint64(e.Filled))
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// APIError wraps a non-2xx HTTP response or an {ok:false} envelope from
+// the Stockfighter API. Callers can type-assert it to tell a rejected
+// order or bad venue apart from a plain network failure.
+type APIError struct {
+	StatusCode int
+	Err        string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("stockfighter: %s (status %d)", e.Err, e.StatusCode)
+}
+
+// okResponse is implemented by every response type via the embedded
+// HasOk, letting do() recognize the API's {ok:false} envelope without
+// knowing the concrete response type.
+type okResponse interface {
+	okStatus() bool
+}
+
+func (h HasOk) okStatus() bool {
+	return h.Ok
+}
+
+// ClientOption configures a Client at construction time.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for REST calls, e.g. to
+// inject a custom transport in tests.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the per-request timeout of the Client's *http.Client.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
+
+// do performs an authenticated REST call and decodes the JSON body into
+// out, retrying per c.retryPolicy and honoring c.rateLimiter. It honors
+// ctx cancellation, sets the Stockfighter API key header, and turns both
+// transport failures and {ok:false}/non-2xx responses into errors
+// instead of swallowing them. body is buffered up front so it can be
+// replayed across retries.
+func (c *Client) do(ctx context.Context, method, url string, body []byte, out interface{}) error {
+	var lastErr error
+
+	for attempt := 0; attempt < c.retryPolicy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleepCtx(ctx, c.retryPolicy.backoff(attempt)); err != nil {
+				return err
+			}
+		}
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		statusCode, err := c.doOnce(ctx, method, url, body, out)
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+		if !c.retryPolicy.shouldRetry(statusCode, err) {
+			return err
+		}
+	}
+
+	return lastErr
+}
+
+// doOnce performs a single attempt of the call do retries, returning the
+// HTTP status code observed (0 on transport failure) alongside any error
+// so do can decide whether to retry.
+func (c *Client) doOnce(ctx context.Context, method, url string, body []byte, out interface{}) (int, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("X-Starfighter-Authorization", c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, apiErrorFrom(resp.StatusCode, raw)
+	}
+
+	if out == nil {
+		return resp.StatusCode, nil
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return resp.StatusCode, err
+	}
+
+	if r, ok := out.(okResponse); ok && !r.okStatus() {
+		return resp.StatusCode, apiErrorFrom(resp.StatusCode, raw)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func apiErrorFrom(statusCode int, raw []byte) error {
+	var envelope StatusResponse
+	json.Unmarshal(raw, &envelope)
+
+	if envelope.Error == "" {
+		envelope.Error = string(raw)
+	}
+
+	return &APIError{StatusCode: statusCode, Err: envelope.Error}
+}
+
+func jsonBody(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
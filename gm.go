@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	GM_LEVEL_URL            = "https://www.stockfighter.io/gm/levels/%s"
+	GM_INSTANCE_URL         = "https://www.stockfighter.io/gm/instances/%d"
+	GM_INSTANCE_STOP_URL    = "https://www.stockfighter.io/gm/instances/%d/stop"
+	GM_INSTANCE_RESTART_URL = "https://www.stockfighter.io/gm/instances/%d/restart"
+	GM_INSTANCE_RESUME_URL  = "https://www.stockfighter.io/gm/instances/%d/resume"
+	GM_INSTANCE_JUDGE_URL   = "https://www.stockfighter.io/gm/instances/%d/judge"
+)
+
+// Instance is one running copy of a Stockfighter training level, as
+// returned by starting, restarting, resuming, or polling it.
+type Instance struct {
+	HasOk
+	Id    int  `json:"instanceId"`
+	Done  bool `json:"done"`
+	Flash struct {
+		Info    string `json:"info"`
+		Warning string `json:"warning"`
+	} `json:"flash"`
+
+	Account              string   `json:"account"`
+	Venues               []string `json:"venues"`
+	Tickers              []string `json:"tickers"`
+	SecondsPerTradingDay int      `json:"secondsPerTradingDay"`
+	InstructionsURL      string   `json:"instructions_url"`
+}
+
+// JudgeResult reports whether the submitted evidence satisfied the
+// level's pass conditions.
+type JudgeResult struct {
+	HasOk
+	Done    bool   `json:"done"`
+	Details string `json:"details"`
+}
+
+// GMService exposes the Stockfighter "Great Machine" level-progression
+// endpoints on top of the same authenticated Client used for trading.
+type GMService struct {
+	client *Client
+}
+
+func (g *GMService) StartLevel(ctx context.Context, name string) (*Instance, error) {
+	inst := new(Instance)
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf(GM_LEVEL_URL, name), nil, inst); err != nil {
+		return nil, err
+	}
+
+	return inst, nil
+}
+
+func (g *GMService) InstanceState(ctx context.Context, id int) (*Instance, error) {
+	inst := new(Instance)
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf(GM_INSTANCE_URL, id), nil, inst); err != nil {
+		return nil, err
+	}
+
+	return inst, nil
+}
+
+func (g *GMService) Stop(ctx context.Context, id int) error {
+	return g.client.do(ctx, http.MethodPost, fmt.Sprintf(GM_INSTANCE_STOP_URL, id), nil, new(StatusResponse))
+}
+
+func (g *GMService) Restart(ctx context.Context, id int) (*Instance, error) {
+	inst := new(Instance)
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf(GM_INSTANCE_RESTART_URL, id), nil, inst); err != nil {
+		return nil, err
+	}
+
+	return inst, nil
+}
+
+func (g *GMService) Resume(ctx context.Context, id int) (*Instance, error) {
+	inst := new(Instance)
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf(GM_INSTANCE_RESUME_URL, id), nil, inst); err != nil {
+		return nil, err
+	}
+
+	return inst, nil
+}
+
+func (g *GMService) Judge(ctx context.Context, id int, evidence string) (*JudgeResult, error) {
+	body, err := jsonBody(struct {
+		Evidence string `json:"evidence"`
+	}{Evidence: evidence})
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(JudgeResult)
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf(GM_INSTANCE_JUDGE_URL, id), body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -1,12 +1,11 @@
 package main
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"flag"
 	"fmt"
-	_ "io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -42,23 +41,23 @@ const (
 )
 
 type Stockfighter interface {
-	Ping() bool
+	Ping(ctx context.Context) (bool, error)
 
-	PingVenue(venue string) bool
+	PingVenue(ctx context.Context, venue string) (bool, error)
 
-	FetchStocks(venue string) []SymbolInfo
-	FetchOrderbook(venue string, stock string) *OrderbookResponse
-	FetchQuote(venue string, stock string) *QuoteResponse
+	FetchStocks(ctx context.Context, venue string) ([]SymbolInfo, error)
+	FetchOrderbook(ctx context.Context, venue string, stock string) (*OrderbookResponse, error)
+	FetchQuote(ctx context.Context, venue string, stock string) (*QuoteResponse, error)
 
-	FetchOrder(venue string, stock string, id int) *OrderResponse
-	PlaceOrder(venue string, stock string, order OrderRequest) *OrderResponse
-	CancelOrder(venue string, stock string, id int) bool
+	FetchOrder(ctx context.Context, venue string, stock string, id int) (*OrderResponse, error)
+	PlaceOrder(ctx context.Context, venue string, stock string, order OrderRequest) (*OrderResponse, error)
+	CancelOrder(ctx context.Context, venue string, stock string, id int) (bool, error)
 
-	FetchAcctOrders(venue string, account string) []OrderResponse
-	FetchAcctStockOrders(venue string, stock string, account string) []OrderResponse
+	FetchAcctOrders(ctx context.Context, venue string, account string) ([]OrderResponse, error)
+	FetchAcctStockOrders(ctx context.Context, venue string, stock string, account string) ([]OrderResponse, error)
 
-	HandleNewQuote()
-	HandleNewOrder()
+	SubscribeQuotes(venue string, account string, stock string) (<-chan QuoteEvent, Unsubscribe)
+	SubscribeExecutions(venue string, account string) (<-chan ExecutionEvent, Unsubscribe)
 }
 
 type HasOk struct {
@@ -144,6 +143,7 @@ type QuoteResponse struct {
 	BidSize  int `json:"bidSize"`
 	BidDepth int `json:"bidDepth"`
 
+	Ask      int `json:"ask"`
 	AskSize  int `json:"askSize"`
 	AskDepth int `json:"askDepth"`
 
@@ -155,222 +155,153 @@ type QuoteResponse struct {
 }
 
 type Client struct {
-	apiKey string
-}
+	apiKey      string
+	httpClient  *http.Client
+	rateLimiter RateLimiter
+	retryPolicy RetryPolicy
 
-func PerformRequest(url string, method string, body *string, js interface{}) error {
-	var resp *http.Response
-	var err error
-
-	switch {
-	case method == http.MethodGet:
-		resp, err = http.Get(url)
-	case method == http.MethodPost:
-		resp, err = http.Post(url, *body, nil)
-	case method == http.MethodDelete:
-		req, err := http.NewRequest(method, url, nil)
-		if err != nil {
-			return err
-		}
-
-		resp, err = http.DefaultClient.Do(req)
-		if err != nil {
-			return err
-		}
-	}
+	GM *GMService
 
-	if err != nil {
-		return errors.New(fmt.Sprintf("Request failed: %d", resp.StatusCode))
-	}
+	mu               sync.Mutex
+	quoteStreams     map[string]*quoteStream
+	executionStreams map[string]*executionStream
+}
 
-	defer resp.Body.Close()
+// NewClient builds a Client authenticated with apiKey. Use it instead of
+// a bare struct literal so the WS stream registries, HTTP client, and GM
+// service are initialized; opts can replace the HTTP client or its
+// timeout and set rate limiting and retries, e.g. WithHTTPClient,
+// WithRateLimit or WithRetryPolicy.
+func NewClient(apiKey string, opts ...ClientOption) *Client {
+	c := &Client{
+		apiKey:           apiKey,
+		httpClient:       &http.Client{Timeout: defaultTimeout},
+		retryPolicy:      DefaultRetryPolicy,
+		quoteStreams:     make(map[string]*quoteStream),
+		executionStreams: make(map[string]*executionStream),
+	}
+	c.GM = &GMService{client: c}
 
-	if err = json.NewDecoder(resp.Body).Decode(&js); err != nil {
-		return err
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	return nil
+	return c
 }
 
-func (c Client) Ping() bool {
+func (c *Client) Ping(ctx context.Context) (bool, error) {
 	s := new(StatusResponse)
-	err := PerformRequest(HEARTBEAT_URL, http.MethodGet, nil, &s)
-
-	if err != nil {
-		return false
-	}
-
-	if s.Ok != true {
-		return false
+	if err := c.do(ctx, http.MethodGet, HEARTBEAT_URL, nil, s); err != nil {
+		return false, err
 	}
 
-	return true
+	return s.Ok, nil
 }
 
-func (c Client) PingVenue(venue string) bool {
+func (c *Client) PingVenue(ctx context.Context, venue string) (bool, error) {
 	s := new(VenueStatusResponse)
-	err := PerformRequest(fmt.Sprintf(VENUE_HEARTBEAT_URL, venue), http.MethodGet, nil, &s)
-
-	if err != nil {
-		return false
-	}
-
-	if s.Ok != true {
-		return false
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf(VENUE_HEARTBEAT_URL, venue), nil, s); err != nil {
+		return false, err
 	}
 
-	return true
+	return s.Ok, nil
 }
 
-func (c Client) FetchStocks(venue string) []SymbolInfo {
+func (c *Client) FetchStocks(ctx context.Context, venue string) ([]SymbolInfo, error) {
 	s := new(VenueStocksResponse)
-	err := PerformRequest(fmt.Sprintf(VENUE_STOCKS_URL, venue), http.MethodGet, nil, &s)
-
-	if err != nil {
-		return nil
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf(VENUE_STOCKS_URL, venue), nil, s); err != nil {
+		return nil, err
 	}
 
-	if s.Ok != true {
-		return nil
-	}
-
-	return s.Symbols
+	return s.Symbols, nil
 }
 
-func (c Client) FetchOrder(venue string, stock string, id int) *OrderResponse {
+func (c *Client) FetchOrder(ctx context.Context, venue string, stock string, id int) (*OrderResponse, error) {
 	s := new(OrderResponse)
-	err := PerformRequest(fmt.Sprintf(VENUE_ORDER_URL, venue, stock, id), http.MethodGet, nil, &s)
-
-	if err != nil {
-		return nil
-	}
-
-	if s.Ok != true {
-		return nil
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf(VENUE_ORDER_URL, venue, stock, id), nil, s); err != nil {
+		return nil, err
 	}
 
 	if s.Venue != venue {
-		return nil
+		return nil, &APIError{Err: "venue mismatch"}
 	}
 
-	return s
+	return s, nil
 }
 
-func (c Client) FetchOrderbook(venue string, stock string) *OrderbookResponse {
+func (c *Client) FetchOrderbook(ctx context.Context, venue string, stock string) (*OrderbookResponse, error) {
 	s := new(OrderbookResponse)
-	err := PerformRequest(fmt.Sprintf(VENUE_ORDERBOOK_URL, venue, stock), http.MethodGet, nil, &s)
-
-	if err != nil {
-		return nil
-	}
-
-	if s.Ok != true {
-		return nil
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf(VENUE_ORDERBOOK_URL, venue, stock), nil, s); err != nil {
+		return nil, err
 	}
 
 	if s.Venue != venue {
-		return nil
+		return nil, &APIError{Err: "venue mismatch"}
 	}
 
 	if s.Symbol != stock {
-		return nil
+		return nil, &APIError{Err: "symbol mismatch"}
 	}
 
-	return s
+	return s, nil
 }
 
-func (c Client) FetchAcctOrders(venue string, account string) []OrderResponse {
+func (c *Client) FetchAcctOrders(ctx context.Context, venue string, account string) ([]OrderResponse, error) {
 	s := new(OrdersStatusResponse)
-	err := PerformRequest(fmt.Sprintf(ACCT_EVERY_ORDERS_URL, account, venue), http.MethodGet, nil, &s)
-
-	if err != nil {
-		return nil
-	}
-
-	if s.Ok != true {
-		return nil
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf(ACCT_EVERY_ORDERS_URL, venue, account), nil, s); err != nil {
+		return nil, err
 	}
 
 	if s.Venue != venue {
-		return nil
+		return nil, &APIError{Err: "venue mismatch"}
 	}
 
-	return s.Orders
+	return s.Orders, nil
 }
 
-func (c Client) FetchAcctStockOrders(venue string, stock string, account string) []OrderResponse {
+func (c *Client) FetchAcctStockOrders(ctx context.Context, venue string, stock string, account string) ([]OrderResponse, error) {
 	s := new(OrdersStatusResponse)
-	err := PerformRequest(fmt.Sprintf(ACCT_STOCK_ORDERS_URL, account, venue, stock), http.MethodGet, nil, &s)
-
-	if err != nil {
-		return nil
-	}
-
-	if s.Ok != true {
-		return nil
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf(ACCT_STOCK_ORDERS_URL, venue, account, stock), nil, s); err != nil {
+		return nil, err
 	}
 
 	if s.Venue != venue {
-		return nil
+		return nil, &APIError{Err: "venue mismatch"}
 	}
 
-	return s.Orders
+	return s.Orders, nil
 }
 
-func (c Client) PlaceOrder(venue string, stock string, order OrderRequest) *OrderResponse {
-	payload, err := json.Marshal(order)
-
+func (c *Client) PlaceOrder(ctx context.Context, venue string, stock string, order OrderRequest) (*OrderResponse, error) {
+	body, err := jsonBody(order)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
 	s := new(OrderResponse)
-	js := string(payload)
-	err = PerformRequest(fmt.Sprintf(VENUE_ORDERS_URL, venue, stock), http.MethodPost, &js, &s)
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf(VENUE_ORDERS_URL, venue, stock), body, s); err != nil {
+		return nil, err
+	}
 
-	return nil
+	return s, nil
 }
 
-func (c Client) FetchQuote(venue string, stock string) *QuoteResponse {
+func (c *Client) FetchQuote(ctx context.Context, venue string, stock string) (*QuoteResponse, error) {
 	s := new(QuoteResponse)
-	err := PerformRequest(fmt.Sprintf(VENUE_QUOTE_URL, venue, stock), http.MethodGet, nil, &s)
-
-	if err != nil {
-		return nil
-	}
-
-	if s.Ok != true {
-		return nil
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf(VENUE_QUOTE_URL, venue, stock), nil, s); err != nil {
+		return nil, err
 	}
 
-	return s
+	return s, nil
 }
 
-func (c Client) CancelOrder(venue string, stock string, id int) bool {
+func (c *Client) CancelOrder(ctx context.Context, venue string, stock string, id int) (bool, error) {
 	s := new(OrderResponse)
-	err := PerformRequest(fmt.Sprintf(VENUE_ORDER_URL, venue, stock, id), http.MethodDelete, nil, &s)
-
-	if err != nil {
-		return false
-	}
-
-	if s.Ok != true {
-		return false
-	}
-
-	if s.Open != false {
-		return false
+	if err := c.do(ctx, http.MethodDelete, fmt.Sprintf(VENUE_ORDER_URL, venue, stock, id), nil, s); err != nil {
+		return false, err
 	}
 
-	return true
-}
-
-func (c Client) HandleNewQuote() {
-
-}
-
-func (c Client) HandleNewOrder() {
-
+	return !s.Open, nil
 }
 
 func Run(client Stockfighter) {
@@ -379,7 +310,7 @@ func Run(client Stockfighter) {
 func main() {
 	var key = flag.String("key", "", "API Key")
 	flag.Parse()
-	c := Client{*key}
+	c := NewClient(*key)
 
 	Run(c)
 }
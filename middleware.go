@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter throttles outgoing requests. The default installed by
+// WithRateLimit wraps golang.org/x/time/rate; callers can supply their
+// own via WithRateLimiter, e.g. to share one limiter across Clients for
+// the same venue.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+type tokenBucketLimiter struct {
+	limiter *rate.Limiter
+}
+
+func (l *tokenBucketLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// WithRateLimit throttles Client.do to rps requests per second, allowing
+// bursts up to burst.
+func WithRateLimit(rps int, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = &tokenBucketLimiter{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+	}
+}
+
+// WithRateLimiter installs a custom RateLimiter.
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// RetryPolicy controls how Client.do retries a failed request. Retries
+// only happen on 429, 5xx, and network errors; anything else (a 4xx
+// rejection, a decode failure) returns immediately.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy retries twice with jittered exponential backoff
+// starting at 250ms.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << uint(attempt-1)
+	if d > p.MaxDelay || d <= 0 {
+		d = p.MaxDelay
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2+1)))
+}
+
+func (p RetryPolicy) shouldRetry(statusCode int, err error) bool {
+	if _, ok := err.(*APIError); !ok {
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// WithRetryPolicy overrides DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+)
+
+type fakeNetError struct{}
+
+func (fakeNetError) Error() string   { return "fake net error" }
+func (fakeNetError) Timeout() bool   { return true }
+func (fakeNetError) Temporary() bool { return true }
+
+// wrappedError mimics *url.Error wrapping a lower-level error, the way
+// http.Client.Do actually returns transport failures.
+type wrappedError struct {
+	err error
+}
+
+func (e *wrappedError) Error() string { return "wrapped: " + e.err.Error() }
+func (e *wrappedError) Unwrap() error { return e.err }
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	var _ net.Error = fakeNetError{}
+
+	cases := []struct {
+		name       string
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{"rate limited", http.StatusTooManyRequests, &APIError{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", http.StatusInternalServerError, &APIError{StatusCode: http.StatusInternalServerError}, true},
+		{"bad request", http.StatusBadRequest, &APIError{StatusCode: http.StatusBadRequest}, false},
+		{"network error", 0, fakeNetError{}, true},
+		{"wrapped network error", 0, &wrappedError{fakeNetError{}}, true},
+		{"decode error", 0, errors.New("invalid character '<'"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DefaultRetryPolicy.shouldRetry(tc.statusCode, tc.err); got != tc.want {
+				t.Fatalf("shouldRetry(%d, %v) = %v, want %v", tc.statusCode, tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+// PlaceOrderRequest builds a PlaceOrder call one field at a time. Build
+// one with Client.NewPlaceOrderRequest, chain setters, then Do(ctx) to
+// send it.
+type PlaceOrderRequest struct {
+	client *Client
+
+	venue   string
+	stock   string
+	account string
+
+	direction Direction
+	orderType OrderType
+
+	qty   int
+	price int
+}
+
+func (c *Client) NewPlaceOrderRequest() *PlaceOrderRequest {
+	return &PlaceOrderRequest{client: c, orderType: Limit}
+}
+
+func (r *PlaceOrderRequest) Venue(venue string) *PlaceOrderRequest {
+	r.venue = venue
+	return r
+}
+
+func (r *PlaceOrderRequest) Stock(stock string) *PlaceOrderRequest {
+	r.stock = stock
+	return r
+}
+
+func (r *PlaceOrderRequest) Account(account string) *PlaceOrderRequest {
+	r.account = account
+	return r
+}
+
+func (r *PlaceOrderRequest) Buy() *PlaceOrderRequest {
+	r.direction = BUY
+	return r
+}
+
+func (r *PlaceOrderRequest) Sell() *PlaceOrderRequest {
+	r.direction = SELL
+	return r
+}
+
+func (r *PlaceOrderRequest) Limit() *PlaceOrderRequest {
+	r.orderType = Limit
+	return r
+}
+
+func (r *PlaceOrderRequest) Market() *PlaceOrderRequest {
+	r.orderType = Market
+	return r
+}
+
+func (r *PlaceOrderRequest) FillOrKill() *PlaceOrderRequest {
+	r.orderType = FOK
+	return r
+}
+
+func (r *PlaceOrderRequest) ImmediateOrCancel() *PlaceOrderRequest {
+	r.orderType = IOC
+	return r
+}
+
+func (r *PlaceOrderRequest) Qty(qty int) *PlaceOrderRequest {
+	r.qty = qty
+	return r
+}
+
+func (r *PlaceOrderRequest) Price(price int) *PlaceOrderRequest {
+	r.price = price
+	return r
+}
+
+func (r *PlaceOrderRequest) Do(ctx context.Context) (*OrderResponse, error) {
+	if r.venue == "" || r.stock == "" || r.account == "" {
+		return nil, errors.New("stockfighter: venue, stock, and account are required")
+	}
+
+	if r.qty <= 0 {
+		return nil, errors.New("stockfighter: qty must be positive")
+	}
+
+	if r.direction == "" {
+		return nil, errors.New("stockfighter: direction is required, call Buy() or Sell()")
+	}
+
+	return r.client.PlaceOrder(ctx, r.venue, r.stock, OrderRequest{
+		Account:   r.account,
+		OrderType: string(r.orderType),
+		Direction: string(r.direction),
+		Qty:       r.qty,
+		Price:     r.price,
+	})
+}
+
+// OrderbookRequest builds a FetchOrderbook call.
+type OrderbookRequest struct {
+	client *Client
+
+	venue string
+	stock string
+}
+
+func (c *Client) NewOrderbookRequest() *OrderbookRequest {
+	return &OrderbookRequest{client: c}
+}
+
+func (r *OrderbookRequest) Venue(venue string) *OrderbookRequest {
+	r.venue = venue
+	return r
+}
+
+func (r *OrderbookRequest) Stock(stock string) *OrderbookRequest {
+	r.stock = stock
+	return r
+}
+
+func (r *OrderbookRequest) Do(ctx context.Context) (*OrderbookResponse, error) {
+	if r.venue == "" || r.stock == "" {
+		return nil, errors.New("stockfighter: venue and stock are required")
+	}
+
+	return r.client.FetchOrderbook(ctx, r.venue, r.stock)
+}
+
+// QuoteRequest builds a FetchQuote call.
+type QuoteRequest struct {
+	client *Client
+
+	venue string
+	stock string
+}
+
+func (c *Client) NewQuoteRequest() *QuoteRequest {
+	return &QuoteRequest{client: c}
+}
+
+func (r *QuoteRequest) Venue(venue string) *QuoteRequest {
+	r.venue = venue
+	return r
+}
+
+func (r *QuoteRequest) Stock(stock string) *QuoteRequest {
+	r.stock = stock
+	return r
+}
+
+func (r *QuoteRequest) Do(ctx context.Context) (*QuoteResponse, error) {
+	if r.venue == "" || r.stock == "" {
+		return nil, errors.New("stockfighter: venue and stock are required")
+	}
+
+	return r.client.FetchQuote(ctx, r.venue, r.stock)
+}
+
+// AccountOrdersRequest builds a FetchAcctOrders/FetchAcctStockOrders
+// call, narrowing to a single stock when Stock is set.
+type AccountOrdersRequest struct {
+	client *Client
+
+	venue   string
+	account string
+	stock   string
+}
+
+func (c *Client) NewAccountOrdersRequest() *AccountOrdersRequest {
+	return &AccountOrdersRequest{client: c}
+}
+
+func (r *AccountOrdersRequest) Venue(venue string) *AccountOrdersRequest {
+	r.venue = venue
+	return r
+}
+
+func (r *AccountOrdersRequest) Account(account string) *AccountOrdersRequest {
+	r.account = account
+	return r
+}
+
+func (r *AccountOrdersRequest) Stock(stock string) *AccountOrdersRequest {
+	r.stock = stock
+	return r
+}
+
+func (r *AccountOrdersRequest) Do(ctx context.Context) ([]OrderResponse, error) {
+	if r.venue == "" || r.account == "" {
+		return nil, errors.New("stockfighter: venue and account are required")
+	}
+
+	if r.stock != "" {
+		return r.client.FetchAcctStockOrders(ctx, r.venue, r.stock, r.account)
+	}
+
+	return r.client.FetchAcctOrders(ctx, r.venue, r.account)
+}
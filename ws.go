@@ -0,0 +1,373 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WS_TICKERTAPE_URL       = "wss://api.stockfighter.io/ob/api/ws/%s/venues/%s/tickertape"
+	WS_TICKERTAPE_STOCK_URL = "wss://api.stockfighter.io/ob/api/ws/%s/venues/%s/tickertape/stocks/%s"
+	WS_EXECUTIONS_URL       = "wss://api.stockfighter.io/ob/api/ws/%s/venues/%s/executions"
+
+	wsPingInterval = 15 * time.Second
+	wsPongTimeout  = 30 * time.Second
+	wsMinBackoff   = 500 * time.Millisecond
+	wsMaxBackoff   = 30 * time.Second
+)
+
+type QuoteEvent struct {
+	HasOk
+	Quote QuoteResponse `json:"quote"`
+}
+
+type ExecutionEvent struct {
+	HasOk
+	Account          string        `json:"account"`
+	Venue            string        `json:"venue"`
+	Symbol           string        `json:"symbol"`
+	Order            OrderResponse `json:"order"`
+	StandingId       int           `json:"standingId"`
+	IncomingId       int           `json:"incomingId"`
+	Price            int           `json:"price"`
+	Filled           int           `json:"filled"`
+	FilledAt         time.Time     `json:"filledAt"`
+	StandingComplete bool          `json:"standingComplete"`
+	IncomingComplete bool          `json:"incomingComplete"`
+}
+
+// Unsubscribe detaches a subscriber from a stream. It is idempotent.
+type Unsubscribe func()
+
+// wsConn owns one reconnecting websocket connection and republishes every
+// frame it reads on raw. Reconnects use exponential backoff with jitter;
+// a ping is sent on wsPingInterval and the read deadline is pushed out on
+// every pong so a silently dead socket is noticed within wsPongTimeout.
+type wsConn struct {
+	url    string
+	apiKey string
+
+	mu     sync.Mutex
+	conn   *websocket.Conn
+	closed bool
+	done   chan struct{}
+
+	raw chan []byte
+}
+
+func dialWSConn(url, apiKey string) *wsConn {
+	c := &wsConn{
+		url:    url,
+		apiKey: apiKey,
+		done:   make(chan struct{}),
+		raw:    make(chan []byte, 64),
+	}
+	go c.run()
+	return c
+}
+
+func (c *wsConn) run() {
+	defer close(c.raw)
+
+	backoff := wsMinBackoff
+
+	for {
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+
+		conn, _, err := websocket.DefaultDialer.Dial(c.url, http.Header{
+			"X-Starfighter-Authorization": []string{c.apiKey},
+		})
+		if err != nil {
+			time.Sleep(jitter(backoff))
+			backoff = nextBackoff(backoff)
+			continue
+		}
+
+		c.mu.Lock()
+		c.conn = conn
+		c.mu.Unlock()
+		backoff = wsMinBackoff
+
+		c.keepAlive(conn)
+
+		if c.readLoop(conn) {
+			return
+		}
+	}
+}
+
+func (c *wsConn) keepAlive(conn *websocket.Conn) {
+	conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		return nil
+	})
+
+	go func() {
+		ticker := time.NewTicker(wsPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// readLoop pumps frames until the connection drops or the stream is
+// closed. It reports whether the caller should stop reconnecting.
+func (c *wsConn) readLoop(conn *websocket.Conn) bool {
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return false
+		}
+
+		select {
+		case c.raw <- msg:
+		case <-c.done:
+			return true
+		}
+	}
+}
+
+func (c *wsConn) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.done)
+
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+func jitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d)))
+}
+
+func nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > wsMaxBackoff {
+		return wsMaxBackoff
+	}
+	return d
+}
+
+// quoteStream fans one tickertape connection out to every subscriber
+// registered for the same venue (and optional stock), so Client only
+// dials once no matter how many callers subscribe.
+type quoteStream struct {
+	conn *wsConn
+
+	mu          sync.Mutex
+	subscribers map[int]chan<- QuoteEvent
+	nextID      int
+}
+
+func newQuoteStream(url, apiKey string) *quoteStream {
+	s := &quoteStream{conn: dialWSConn(url, apiKey), subscribers: make(map[int]chan<- QuoteEvent)}
+	go s.dispatch()
+	return s
+}
+
+func (s *quoteStream) dispatch() {
+	for raw := range s.conn.raw {
+		var evt QuoteEvent
+		if err := json.Unmarshal(raw, &evt); err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		for _, ch := range s.subscribers {
+			select {
+			case ch <- evt:
+			default:
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *quoteStream) subscribe() (int, <-chan QuoteEvent) {
+	ch := make(chan QuoteEvent, 16)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	id := s.nextID
+	s.subscribers[id] = ch
+
+	return id, ch
+}
+
+func (s *quoteStream) unsubscribe(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ch, ok := s.subscribers[id]; ok {
+		delete(s.subscribers, id)
+		close(ch)
+	}
+}
+
+func (s *quoteStream) refCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+// executionStream is quoteStream's counterpart for the per-account fills
+// feed. It is kept as a separate type rather than shared generic code
+// because the two feeds decode into unrelated event types.
+type executionStream struct {
+	conn *wsConn
+
+	mu          sync.Mutex
+	subscribers map[int]chan<- ExecutionEvent
+	nextID      int
+}
+
+func newExecutionStream(url, apiKey string) *executionStream {
+	s := &executionStream{conn: dialWSConn(url, apiKey), subscribers: make(map[int]chan<- ExecutionEvent)}
+	go s.dispatch()
+	return s
+}
+
+func (s *executionStream) dispatch() {
+	for raw := range s.conn.raw {
+		var evt ExecutionEvent
+		if err := json.Unmarshal(raw, &evt); err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		for _, ch := range s.subscribers {
+			select {
+			case ch <- evt:
+			default:
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *executionStream) subscribe() (int, <-chan ExecutionEvent) {
+	ch := make(chan ExecutionEvent, 16)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	id := s.nextID
+	s.subscribers[id] = ch
+
+	return id, ch
+}
+
+func (s *executionStream) unsubscribe(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ch, ok := s.subscribers[id]; ok {
+		delete(s.subscribers, id)
+		close(ch)
+	}
+}
+
+func (s *executionStream) refCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+func (c *Client) SubscribeQuotes(venue string, account string, stock string) (<-chan QuoteEvent, Unsubscribe) {
+	key := venue
+	url := fmt.Sprintf(WS_TICKERTAPE_URL, account, venue)
+	if stock != "" {
+		key = venue + ":" + stock
+		url = fmt.Sprintf(WS_TICKERTAPE_STOCK_URL, account, venue, stock)
+	}
+
+	c.mu.Lock()
+	s, ok := c.quoteStreams[key]
+	if !ok {
+		s = newQuoteStream(url, c.apiKey)
+		c.quoteStreams[key] = s
+	}
+	c.mu.Unlock()
+
+	id, ch := s.subscribe()
+
+	return ch, func() {
+		s.unsubscribe(id)
+		c.reapQuoteStream(key, s)
+	}
+}
+
+func (c *Client) reapQuoteStream(key string, s *quoteStream) {
+	if s.refCount() > 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.quoteStreams[key] == s {
+		delete(c.quoteStreams, key)
+		s.conn.Close()
+	}
+}
+
+func (c *Client) SubscribeExecutions(venue string, account string) (<-chan ExecutionEvent, Unsubscribe) {
+	key := venue + ":" + account
+	url := fmt.Sprintf(WS_EXECUTIONS_URL, account, venue)
+
+	c.mu.Lock()
+	s, ok := c.executionStreams[key]
+	if !ok {
+		s = newExecutionStream(url, c.apiKey)
+		c.executionStreams[key] = s
+	}
+	c.mu.Unlock()
+
+	id, ch := s.subscribe()
+
+	return ch, func() {
+		s.unsubscribe(id)
+		c.reapExecutionStream(key, s)
+	}
+}
+
+func (c *Client) reapExecutionStream(key string, s *executionStream) {
+	if s.refCount() > 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.executionStreams[key] == s {
+		delete(c.executionStreams, key)
+		s.conn.Close()
+	}
+}